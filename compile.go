@@ -0,0 +1,160 @@
+package ptr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Accessor is a pre-compiled, repeatable evaluator for a single
+// JSONPointer, produced by Compile.
+type Accessor func(document interface{}) (interface{}, error)
+
+// compiledStep is a reference token with its decoding already done by
+// New, plus whatever Compile can work out once rather than on every
+// call: whether it parses as a non-negative array index, and if so,
+// what that index is.
+type compiledStep struct {
+	token   string
+	index   int
+	isIndex bool
+}
+
+// Compile pre-processes ptr's reference tokens once - parsing numeric
+// tokens to int ahead of time - and returns an Accessor that evaluates
+// them against many documents without repeating that work, or a struct
+// field scan, on every call. It's meant for hot paths that Get the same
+// pointer against many documents, such as OpenAPI $ref resolution or a
+// JSON Patch application loop.
+func (ptr *JSONPointer) Compile() Accessor {
+	steps := make([]compiledStep, len(ptr.references))
+	for i, tk := range ptr.references {
+		s := compiledStep{token: tk}
+		if idx, err := strconv.Atoi(tk); err == nil && idx >= 0 {
+			s.index = idx
+			s.isIndex = true
+		}
+		steps[i] = s
+	}
+
+	return func(document interface{}) (interface{}, error) {
+		current := document
+		for _, s := range steps {
+			next, err := compiledStepInto(current, s)
+			if err != nil {
+				return nil, err
+			}
+			current = next
+		}
+		return unbox(current), nil
+	}
+}
+
+// compiledStepInto is the Accessor-side counterpart of step/resolveStep:
+// it reads the member of current identified by s, using s's pre-parsed
+// index instead of calling strconv.Atoi again.
+func compiledStepInto(current interface{}, s compiledStep) (interface{}, error) {
+	switch t := current.(type) {
+	case map[string]interface{}:
+		v, ok := t[s.token]
+		if !ok {
+			return nil, fmt.Errorf("object does not have the key %q", s.token)
+		}
+		return v, nil
+	case []interface{}:
+		if !s.isIndex || len(t) <= s.index {
+			return nil, fmt.Errorf("out of bound [0,%d[, index %q", len(t), s.token)
+		}
+		return t[s.index], nil
+	default:
+		return resolveStepCompiled(current, s)
+	}
+}
+
+// resolveStepCompiled is resolveStep's counterpart for a compiled step:
+// it resolves a struct field through fieldCache instead of scanning the
+// struct's fields on every call, and reuses s's pre-parsed array index.
+func resolveStepCompiled(current interface{}, s compiledStep) (interface{}, error) {
+	if jp, ok := current.(JSONPointable); ok {
+		return jp.JSONLookup(s.token)
+	}
+
+	v, err := indirect(valueOf(current), s.token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		field, err := cachedStructField(v.Type(), s.token)
+		if err != nil {
+			return nil, err
+		}
+		return box(v.FieldByIndex(field.Index)), nil
+	case reflect.Map:
+		key, err := mapKey(v.Type(), s.token)
+		if err != nil {
+			return nil, err
+		}
+		found := v.MapIndex(key)
+		if !found.IsValid() {
+			return nil, fmt.Errorf("object does not have the key %q", s.token)
+		}
+		return found.Interface(), nil
+	case reflect.Slice, reflect.Array:
+		if !s.isIndex || v.Len() <= s.index {
+			return nil, fmt.Errorf("out of bound [0,%d[, index %q", v.Len(), s.token)
+		}
+		return box(v.Index(s.index)), nil
+	default:
+		return nil, fmt.Errorf("invalid token reference %q", s.token)
+	}
+}
+
+// fieldCache holds, per concrete struct type, a map from JSON Pointer
+// reference token to the reflect.StructField it resolves to - the same
+// tag-matching rules as structField, computed once per type instead of
+// scanned on every resolveStepCompiled call. It mirrors the field-map
+// cache encoding/json keeps for the same reason.
+var fieldCache sync.Map // map[reflect.Type]map[string]reflect.StructField
+
+func cachedStructField(t reflect.Type, token string) (reflect.StructField, error) {
+	fields, ok := fieldCache.Load(t)
+	if !ok {
+		fields, _ = fieldCache.LoadOrStore(t, buildFieldMap(t))
+	}
+
+	field, ok := fields.(map[string]reflect.StructField)[token]
+	if !ok {
+		return reflect.StructField{}, fmt.Errorf("struct %s has no field for token %q", t, token)
+	}
+	return field, nil
+}
+
+func buildFieldMap(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			tagName := strings.SplitN(tag, ",", 2)[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		if _, dup := fields[name]; !dup {
+			fields[name] = f // first match wins, as in structField
+		}
+	}
+	return fields
+}
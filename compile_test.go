@@ -0,0 +1,107 @@
+package ptr_test
+
+import (
+	"reflect"
+	"testing"
+
+	ptr "github.com/oas3/json-pointer"
+)
+
+func TestCompile(t *testing.T) {
+	doc := map[string]interface{}{
+		"foo": []interface{}{"bar", "baz"},
+	}
+	p := &person{
+		Name:      "Ada",
+		Addresses: []address{{City: "London", Geo: &geo{Lat: 51.5}}},
+		Tags:      map[string]string{"role": "engineer"},
+	}
+
+	tests := []struct {
+		pointer  string
+		document interface{}
+	}{
+		{"/foo/1", doc},
+		{"/name", p},
+		{"/addresses/0/city", p},
+		{"/addresses/0/geo/lat", p},
+		{"/tags/role", p},
+	}
+
+	for _, tt := range tests {
+		pp, err := ptr.New(tt.pointer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := pp.Get(tt.document)
+		if err != nil {
+			t.Fatalf("%s: Get: %v", tt.pointer, err)
+		}
+
+		accessor := pp.Compile()
+		got, err := accessor(tt.document)
+		if err != nil {
+			t.Fatalf("%s: Accessor: %v", tt.pointer, err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Accessor() = %v, want %v (from Get)", tt.pointer, got, want)
+		}
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	doc := map[string]interface{}{"foo": "bar"}
+
+	pp, err := ptr.New("/missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accessor := pp.Compile()
+	if _, err := accessor(doc); err == nil {
+		t.Fatal("expected an error for a missing key")
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	p := &person{
+		Name:      "Ada",
+		Addresses: []address{{City: "London", Geo: &geo{Lat: 51.5}}},
+		Tags:      map[string]string{"role": "engineer"},
+	}
+
+	pp, err := ptr.New("/addresses/0/city")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pp.Get(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAccessor(b *testing.B) {
+	p := &person{
+		Name:      "Ada",
+		Addresses: []address{{City: "London", Geo: &geo{Lat: 51.5}}},
+		Tags:      map[string]string{"role": "engineer"},
+	}
+
+	pp, err := ptr.New("/addresses/0/city")
+	if err != nil {
+		b.Fatal(err)
+	}
+	accessor := pp.Compile()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := accessor(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
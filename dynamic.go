@@ -0,0 +1,283 @@
+package ptr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONPointable lets a type resolve its own JSON Pointer reference
+// tokens. Implementing it allows Get/Set/Delete to evaluate a pointer
+// against a custom container (e.g. a decoded OpenAPI model) without
+// first round-tripping it through encoding/json. Delete can pass
+// through such a container, but can not remove one of its members: it
+// returns an error when the pointer's final token names a member of
+// anything other than a map[string]interface{} or []interface{}.
+type JSONPointable interface {
+	JSONLookup(token string) (interface{}, error)
+}
+
+// JSONSetable is the write-side counterpart of JSONPointable.
+type JSONSetable interface {
+	JSONSet(token string, value interface{}) error
+}
+
+// addressable carries a reflect.Value for a struct or array that was
+// reached through a struct field or a slice/array element, so that a
+// later setStep can still mutate it in place. Boxing such a value with
+// plain reflect.Value.Interface() would copy it out and disconnect any
+// further mutation from the original document; addressable keeps the
+// connection alive between traversal steps. It never escapes to a
+// caller: unbox strips it from anything returned to package callers.
+type addressable struct {
+	value reflect.Value
+}
+
+// box converts a navigated reflect.Value into the interface{} that
+// traverseRoot/descend carry as `current`.
+func box(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Array:
+		return addressable{v}
+	default:
+		return v.Interface()
+	}
+}
+
+// unbox reverses box, so a struct or array that a pointer happens to
+// resolve to is returned to the caller as a plain value, never as the
+// internal addressable wrapper.
+func unbox(current interface{}) interface{} {
+	if a, ok := current.(addressable); ok {
+		return a.value.Interface()
+	}
+	return current
+}
+
+// kindOf reports the reflect.Kind of current, unwrapping addressable
+// first so it reflects the wrapped value rather than the wrapper.
+func kindOf(current interface{}) reflect.Kind {
+	if a, ok := current.(addressable); ok {
+		return a.value.Kind()
+	}
+	return reflect.ValueOf(current).Kind()
+}
+
+// valueOf is the read side of addressable: it recovers the
+// reflect.Value a step left off at, whether or not it was boxed.
+func valueOf(current interface{}) reflect.Value {
+	if a, ok := current.(addressable); ok {
+		return a.value
+	}
+	return reflect.ValueOf(current)
+}
+
+// resolveStep reads the member of current identified by token, used
+// when current is neither a map[string]interface{} nor a
+// []interface{}. It checks JSONPointable first, then falls back to
+// reflection: it transparently follows pointers, matches struct fields
+// against their `json:"..."` tag (or field name if untagged), and reads
+// named map and slice/array types by key or index.
+func resolveStep(current interface{}, token string) (interface{}, error) {
+	if jp, ok := current.(JSONPointable); ok {
+		return jp.JSONLookup(token)
+	}
+
+	v, err := indirect(valueOf(current), token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		field, err := structField(v.Type(), token)
+		if err != nil {
+			return nil, err
+		}
+		return box(v.FieldByIndex(field.Index)), nil
+	case reflect.Map:
+		key, err := mapKey(v.Type(), token)
+		if err != nil {
+			return nil, err
+		}
+		found := v.MapIndex(key)
+		if !found.IsValid() {
+			return nil, fmt.Errorf("object does not have the key %q", token)
+		}
+		return found.Interface(), nil
+	case reflect.Slice, reflect.Array:
+		idx, err := arrayIndex(v, token)
+		if err != nil {
+			return nil, err
+		}
+		return box(v.Index(idx)), nil
+	default:
+		return nil, fmt.Errorf("invalid token reference %q", token)
+	}
+}
+
+// missingMapKey reports whether current is, or transparently
+// dereferences to, a map whose key type matches token but which does
+// not yet hold that key — the one case resolveStep treats as an error
+// but setStep can still handle, by creating the entry.
+func missingMapKey(current interface{}, token string) bool {
+	v, err := indirect(valueOf(current), token)
+	if err != nil || v.Kind() != reflect.Map {
+		return false
+	}
+	key, err := mapKey(v.Type(), token)
+	if err != nil {
+		return false
+	}
+	return !v.MapIndex(key).IsValid()
+}
+
+// setStep assigns value to the member of current identified by token,
+// the write-side counterpart of resolveStep. current must be, or
+// transparently dereference to, an addressable struct, map or
+// slice/array; a plain (non-pointer) struct or array value can't be
+// mutated through reflection and returns an error.
+func setStep(current interface{}, token string, value interface{}) error {
+	if js, ok := current.(JSONSetable); ok {
+		return js.JSONSet(token, value)
+	}
+
+	v, err := indirect(valueOf(current), token)
+	if err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		field, err := structField(v.Type(), token)
+		if err != nil {
+			return err
+		}
+		fv := v.FieldByIndex(field.Index)
+		if !fv.CanSet() {
+			return fmt.Errorf("field for token %q is not settable; pass a pointer to the struct", token)
+		}
+		rv, err := assignableValue(value, fv.Type())
+		if err != nil {
+			return err
+		}
+		fv.Set(rv)
+		return nil
+	case reflect.Map:
+		key, err := mapKey(v.Type(), token)
+		if err != nil {
+			return err
+		}
+		if v.IsNil() {
+			return fmt.Errorf("map field for token %q is nil; initialize it first", token)
+		}
+		rv, err := assignableValue(value, v.Type().Elem())
+		if err != nil {
+			return err
+		}
+		v.SetMapIndex(key, rv)
+		return nil
+	case reflect.Slice, reflect.Array:
+		idx, err := arrayIndex(v, token)
+		if err != nil {
+			return err
+		}
+		ev := v.Index(idx)
+		if !ev.CanSet() {
+			return fmt.Errorf("element for token %q is not settable; pass a pointer to the slice", token)
+		}
+		rv, err := assignableValue(value, ev.Type())
+		if err != nil {
+			return err
+		}
+		ev.Set(rv)
+		return nil
+	default:
+		return fmt.Errorf("invalid token reference %q", token)
+	}
+}
+
+// indirect follows pointers until it reaches the value they ultimately
+// point to, erroring out on a nil pointer along the way.
+func indirect(v reflect.Value, token string) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("nil pointer while resolving token %q", token)
+		}
+		v = v.Elem()
+	}
+	return v, nil
+}
+
+// structField finds the exported field of t addressed by token,
+// matching against the field's `json:"..."` tag name when present
+// (honoring a "-" tag as "not part of the JSON representation") and
+// falling back to the Go field name otherwise.
+func structField(t reflect.Type, token string) (reflect.StructField, error) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			tagName := strings.SplitN(tag, ",", 2)[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		if name == token {
+			return f, nil
+		}
+	}
+	return reflect.StructField{}, fmt.Errorf("struct %s has no field for token %q", t, token)
+}
+
+// mapKey converts token to m's key type. Only string and named-string
+// key types are supported, since a JSON Pointer reference token is
+// always a string.
+func mapKey(t reflect.Type, token string) (reflect.Value, error) {
+	key := t.Key()
+	if key.Kind() != reflect.String {
+		return reflect.Value{}, fmt.Errorf("map with non-string keys is not supported")
+	}
+	return reflect.ValueOf(token).Convert(key), nil
+}
+
+// arrayIndex parses token as an array index into v, applying the same
+// bounds rules as the []interface{} fast path in traverseRoot.
+func arrayIndex(v reflect.Value, token string) (int, error) {
+	idx, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if idx < 0 || v.Len() <= idx {
+		return 0, fmt.Errorf("out of bound [0,%d[, index %q", v.Len(), token)
+	}
+	return idx, nil
+}
+
+// assignableValue wraps value so it can be assigned into a field of
+// type t, converting it wherever reflect allows the conversion (e.g. a
+// plain string into a named string type such as encoding/json.Number,
+// or a float64 into an int field) where a direct assignment would
+// otherwise fail.
+func assignableValue(value interface{}, t reflect.Type) (reflect.Value, error) {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return reflect.Zero(t), nil
+	}
+	if rv.Type().AssignableTo(t) {
+		return rv, nil
+	}
+	if rv.Type().ConvertibleTo(t) {
+		return rv.Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("value of type %s is not assignable to %s", rv.Type(), t)
+}
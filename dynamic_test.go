@@ -0,0 +1,165 @@
+package ptr_test
+
+import (
+	"reflect"
+	"testing"
+
+	ptr "github.com/oas3/json-pointer"
+)
+
+type address struct {
+	City string `json:"city"`
+	Geo  *geo   `json:"geo,omitempty"`
+}
+
+type geo struct {
+	Lat float64 `json:"lat"`
+}
+
+type person struct {
+	Name      string            `json:"name"`
+	Addresses []address         `json:"addresses"`
+	Tags      map[string]string `json:"tags"`
+}
+
+func TestGetReflectedStruct(t *testing.T) {
+	p := &person{
+		Name: "Ada",
+		Addresses: []address{
+			{City: "London", Geo: &geo{Lat: 51.5}},
+		},
+		Tags: map[string]string{"role": "engineer"},
+	}
+
+	tests := []struct {
+		pointer string
+		want    interface{}
+	}{
+		{"/name", "Ada"},
+		{"/addresses/0/city", "London"},
+		{"/addresses/0/geo/lat", 51.5},
+		{"/tags/role", "engineer"},
+	}
+
+	for _, tt := range tests {
+		pp, err := ptr.New(tt.pointer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := pp.Get(p)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.pointer, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.pointer, got, tt.want)
+		}
+	}
+}
+
+func TestSetReflectedStruct(t *testing.T) {
+	p := &person{
+		Name:      "Ada",
+		Addresses: []address{{City: "London"}},
+		Tags:      map[string]string{},
+	}
+
+	cases := []struct {
+		pointer string
+		value   interface{}
+	}{
+		{"/name", "Grace"},
+		{"/addresses/0/city", "New York"},
+		{"/tags/role", "admiral"},
+	}
+
+	for _, c := range cases {
+		pp, err := ptr.New(c.pointer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := pp.Set(c.value, p); err != nil {
+			t.Fatalf("%s: %v", c.pointer, err)
+		}
+	}
+
+	if p.Name != "Grace" {
+		t.Errorf("Name = %q, want %q", p.Name, "Grace")
+	}
+	if p.Addresses[0].City != "New York" {
+		t.Errorf("City = %q, want %q", p.Addresses[0].City, "New York")
+	}
+	if p.Tags["role"] != "admiral" {
+		t.Errorf("Tags[role] = %q, want %q", p.Tags["role"], "admiral")
+	}
+}
+
+func TestSetReflectedStructValueNotAddressable(t *testing.T) {
+	p := person{Name: "Ada"}
+
+	pp, err := ptr.New("/name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := pp.Set("Grace", p); err == nil {
+		t.Fatal("expected an error setting a field on a non-pointer struct")
+	}
+}
+
+func TestSetReflectedStructNilMap(t *testing.T) {
+	p := &person{Name: "Ada"}
+
+	pp, err := ptr.New("/tags/role")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := pp.Set("admiral", p); err == nil {
+		t.Fatal("expected an error setting a key on a nil map field")
+	}
+}
+
+type customModel struct {
+	fields map[string]interface{}
+}
+
+func (m *customModel) JSONLookup(token string) (interface{}, error) {
+	v, ok := m.fields[token]
+	if !ok {
+		return nil, errNoSuchToken(token)
+	}
+	return v, nil
+}
+
+func (m *customModel) JSONSet(token string, value interface{}) error {
+	m.fields[token] = value
+	return nil
+}
+
+type errNoSuchToken string
+
+func (e errNoSuchToken) Error() string {
+	return "no such token: " + string(e)
+}
+
+func TestJSONPointableAndSetable(t *testing.T) {
+	m := &customModel{fields: map[string]interface{}{"foo": "bar"}}
+
+	p, err := ptr.New("/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := p.Get(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "bar" {
+		t.Errorf("Get = %v, want %q", got, "bar")
+	}
+
+	if _, _, err := p.Set("baz", m); err != nil {
+		t.Fatal(err)
+	}
+	if m.fields["foo"] != "baz" {
+		t.Errorf("fields[foo] = %v, want %q", m.fields["foo"], "baz")
+	}
+}
@@ -0,0 +1,211 @@
+// Package patch implements JSON Patch as described by RFC 6902, built on
+// top of the JSON Pointer primitives in github.com/oas3/json-pointer.
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	ptr "github.com/oas3/json-pointer"
+)
+
+// Op identifies the operation of a single JSON Patch entry, as defined
+// by RFC 6902 §4.
+type Op string
+
+// The six operations defined by RFC 6902 §4.
+const (
+	OpAdd     Op = "add"
+	OpRemove  Op = "remove"
+	OpReplace Op = "replace"
+	OpMove    Op = "move"
+	OpCopy    Op = "copy"
+	OpTest    Op = "test"
+)
+
+// Operation is a single entry of a JSON Patch document.
+type Operation struct {
+	Op    Op              `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is an ordered list of JSON Patch operations. It unmarshals
+// directly from the standard JSON Patch array form, e.g.
+// `[{"op":"add","path":"/a/b","value":1}]`.
+type Patch []Operation
+
+// Apply applies the patch to doc in order and returns the resulting
+// document. Operations are applied to a deep copy of doc, so a failing
+// operation leaves doc itself untouched; the error identifies which
+// operation, by index and path, caused the failure.
+func (p Patch) Apply(doc interface{}) (interface{}, error) {
+	result, err := deepCopy(doc)
+	if err != nil {
+		return nil, fmt.Errorf("copying document: %w", err)
+	}
+
+	for i, op := range p {
+		result, err = op.apply(result)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return result, nil
+}
+
+func (o Operation) apply(doc interface{}) (interface{}, error) {
+	switch o.Op {
+	case OpAdd:
+		return applyAdd(doc, o.Path, o.Value)
+	case OpRemove:
+		return applyRemove(doc, o.Path)
+	case OpReplace:
+		return applyReplace(doc, o.Path, o.Value)
+	case OpMove:
+		return applyMove(doc, o.From, o.Path)
+	case OpCopy:
+		return applyCopy(doc, o.From, o.Path)
+	case OpTest:
+		return applyTest(doc, o.Path, o.Value)
+	default:
+		return nil, fmt.Errorf("unsupported op %q", o.Op)
+	}
+}
+
+func applyAdd(doc interface{}, path string, raw json.RawMessage) (interface{}, error) {
+	value, err := decodeValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ptr.New(path)
+	if err != nil {
+		return nil, err
+	}
+	return p.Insert(value, doc)
+}
+
+func applyRemove(doc interface{}, path string) (interface{}, error) {
+	p, err := ptr.New(path)
+	if err != nil {
+		return nil, err
+	}
+	return p.Remove(doc)
+}
+
+func applyReplace(doc interface{}, path string, raw json.RawMessage) (interface{}, error) {
+	value, err := decodeValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ptr.New(path)
+	if err != nil {
+		return nil, err
+	}
+	// Set/Put would silently create a missing object member; replace
+	// must fail instead, so confirm the member already exists first.
+	if _, err := p.Get(doc); err != nil {
+		return nil, err
+	}
+	return p.Put(value, doc)
+}
+
+func applyMove(doc interface{}, from, path string) (interface{}, error) {
+	if from == path || strings.HasPrefix(path, from+"/") {
+		return nil, fmt.Errorf("path %q: cannot move location %q into itself", path, from)
+	}
+
+	fromPtr, err := ptr.New(from)
+	if err != nil {
+		return nil, err
+	}
+	value, err := fromPtr.Get(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err = fromPtr.Remove(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	toPtr, err := ptr.New(path)
+	if err != nil {
+		return nil, err
+	}
+	return toPtr.Insert(value, doc)
+}
+
+func applyCopy(doc interface{}, from, path string) (interface{}, error) {
+	fromPtr, err := ptr.New(from)
+	if err != nil {
+		return nil, err
+	}
+	value, err := fromPtr.Get(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	copied, err := deepCopy(value)
+	if err != nil {
+		return nil, fmt.Errorf("copying value at %q: %w", from, err)
+	}
+
+	toPtr, err := ptr.New(path)
+	if err != nil {
+		return nil, err
+	}
+	return toPtr.Insert(copied, doc)
+}
+
+func applyTest(doc interface{}, path string, raw json.RawMessage) (interface{}, error) {
+	value, err := decodeValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ptr.New(path)
+	if err != nil {
+		return nil, err
+	}
+	got, err := p.Get(doc)
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(got, value) {
+		return nil, fmt.Errorf("path %q: test failed", path)
+	}
+	return doc, nil
+}
+
+func decodeValue(raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	return v, nil
+}
+
+// deepCopy clones a decoded JSON document (maps, slices and scalars) by
+// round-tripping it through encoding/json, so operations apply to an
+// isolated copy rather than aliasing the caller's original. It returns
+// an error rather than falling back to v unchanged: silently aliasing
+// would let a patch that fails partway through mutate the caller's
+// document in place.
+func deepCopy(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling for deep copy: %w", err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("unmarshaling for deep copy: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,167 @@
+package patch_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/oas3/json-pointer/patch"
+)
+
+func ExamplePatch_Apply() {
+	doc := map[string]interface{}{
+		"foo": "bar",
+	}
+
+	var p patch.Patch
+	_ = json.Unmarshal([]byte(`[{"op":"add","path":"/baz","value":"qux"}]`), &p)
+
+	result, _ := p.Apply(doc)
+	fmt.Println(doc)
+	fmt.Println(result)
+
+	// Output:
+	// map[foo:bar]
+	// map[baz:qux foo:bar]
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     interface{}
+		patch   string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:  "add object member",
+			doc:   map[string]interface{}{"foo": "bar"},
+			patch: `[{"op":"add","path":"/baz","value":"qux"}]`,
+			want:  map[string]interface{}{"foo": "bar", "baz": "qux"},
+		},
+		{
+			name:  "add array append",
+			doc:   map[string]interface{}{"foo": []interface{}{"bar"}},
+			patch: `[{"op":"add","path":"/foo/-","value":"baz"}]`,
+			want:  map[string]interface{}{"foo": []interface{}{"bar", "baz"}},
+		},
+		{
+			name:  "add array insert",
+			doc:   map[string]interface{}{"foo": []interface{}{"bar", "baz"}},
+			patch: `[{"op":"add","path":"/foo/0","value":"qux"}]`,
+			want:  map[string]interface{}{"foo": []interface{}{"qux", "bar", "baz"}},
+		},
+		{
+			name:  "add at root array index",
+			doc:   []interface{}{"a", "b"},
+			patch: `[{"op":"add","path":"/0","value":"z"}]`,
+			want:  []interface{}{"z", "a", "b"},
+		},
+		{
+			name:  "remove object member",
+			doc:   map[string]interface{}{"foo": "bar", "baz": "qux"},
+			patch: `[{"op":"remove","path":"/baz"}]`,
+			want:  map[string]interface{}{"foo": "bar"},
+		},
+		{
+			name:  "remove root array element",
+			doc:   []interface{}{"a", "b", "c"},
+			patch: `[{"op":"remove","path":"/1"}]`,
+			want:  []interface{}{"a", "c"},
+		},
+		{
+			name:  "replace",
+			doc:   map[string]interface{}{"foo": "bar"},
+			patch: `[{"op":"replace","path":"/foo","value":"baz"}]`,
+			want:  map[string]interface{}{"foo": "baz"},
+		},
+		{
+			name:    "replace missing member fails",
+			doc:     map[string]interface{}{"foo": "bar"},
+			patch:   `[{"op":"replace","path":"/missing","value":"baz"}]`,
+			wantErr: true,
+		},
+		{
+			name:  "move",
+			doc:   map[string]interface{}{"foo": map[string]interface{}{"bar": "baz"}},
+			patch: `[{"op":"move","from":"/foo/bar","path":"/qux"}]`,
+			want:  map[string]interface{}{"foo": map[string]interface{}{}, "qux": "baz"},
+		},
+		{
+			name:    "move into itself fails",
+			doc:     map[string]interface{}{"foo": map[string]interface{}{"bar": "baz"}},
+			patch:   `[{"op":"move","from":"/foo","path":"/foo/bar"}]`,
+			wantErr: true,
+		},
+		{
+			name:  "copy",
+			doc:   map[string]interface{}{"foo": "bar"},
+			patch: `[{"op":"copy","from":"/foo","path":"/baz"}]`,
+			want:  map[string]interface{}{"foo": "bar", "baz": "bar"},
+		},
+		{
+			name:  "test success leaves document unchanged",
+			doc:   map[string]interface{}{"foo": "bar"},
+			patch: `[{"op":"test","path":"/foo","value":"bar"}]`,
+			want:  map[string]interface{}{"foo": "bar"},
+		},
+		{
+			name:    "test failure",
+			doc:     map[string]interface{}{"foo": "bar"},
+			patch:   `[{"op":"test","path":"/foo","value":"baz"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "remove root fails",
+			doc:     map[string]interface{}{"foo": "bar"},
+			patch:   `[{"op":"remove","path":""}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p patch.Patch
+			if err := json.Unmarshal([]byte(tt.patch), &p); err != nil {
+				t.Fatalf("unmarshal patch: %v", err)
+			}
+
+			got, err := p.Apply(tt.doc)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got result %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyRevertsOnFailure(t *testing.T) {
+	doc := map[string]interface{}{"foo": "bar"}
+
+	var p patch.Patch
+	err := json.Unmarshal([]byte(`[
+		{"op":"replace","path":"/foo","value":"changed"},
+		{"op":"remove","path":"/missing"}
+	]`), &p)
+	if err != nil {
+		t.Fatalf("unmarshal patch: %v", err)
+	}
+
+	if _, err := p.Apply(doc); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	want := map[string]interface{}{"foo": "bar"}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("document was mutated despite the failed patch: %v", doc)
+	}
+}
@@ -3,13 +3,15 @@ package ptr
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 )
 
 var (
-	prefixErr = errors.New("a JSON Pointer is prefixed by a '/' (%x2F) character")
+	prefixErr         = errors.New("a JSON Pointer is prefixed by a '/' (%x2F) character")
+	fragmentPrefixErr = errors.New("a JSON Pointer fragment is prefixed by a '#' (%x23) character")
 )
 
 // New creates a JSON Pointer based on the given string.
@@ -26,9 +28,35 @@ func New(ptr string) (JSONPointer, error) {
 		return JSONPointer{}, prefixErr
 	}
 
-	return JSONPointer{
-		references: strings.Split(ptr[1:], "/"),
-	}, nil
+	parts := strings.Split(ptr[1:], "/")
+	references := make([]string, len(parts))
+	for i, p := range parts {
+		references[i] = decode(p)
+	}
+
+	return JSONPointer{references: references}, nil
+}
+
+// NewFromFragment creates a JSON Pointer from its URI fragment
+// identifier representation, as described by RFC 6901 §6: a pointer
+// prefixed with '#', with each reference token percent-encoded (the
+// '/' that separates tokens is left literal; a literal '/' or '~'
+// within a token is still escaped as '~1'/'~0', same as String()).
+func NewFromFragment(fragment string) (JSONPointer, error) {
+	if fragment == "" {
+		return JSONPointer{}, nil
+	}
+
+	if fragment[0] != '#' {
+		return JSONPointer{}, fragmentPrefixErr
+	}
+
+	unescaped, err := url.PathUnescape(fragment[1:])
+	if err != nil {
+		return JSONPointer{}, fmt.Errorf("invalid percent-encoding: %w", err)
+	}
+
+	return New(unescaped)
 }
 
 // JSONPointer represents a JavaScript Object Notation (JSON) Pointer.
@@ -41,6 +69,9 @@ type JSONPointer struct {
 // If the document is an array and the pointer removes an element at the
 // root level, an error will be returned. Since the document can not be
 // updated since the array needs te be recreated. (see examples)
+//
+// Callers that need to remove a root-level array element should use
+// Remove instead, which returns the resulting root document.
 func (ptr *JSONPointer) Delete(document interface{}) (interface{}, error) {
 	doc, _, err := ptr.traverse(nil, document, true)
 	return doc, err
@@ -57,11 +88,160 @@ func (ptr *JSONPointer) Set(value, document interface{}) (interface{}, reflect.K
 	return ptr.traverse(value, document, false)
 }
 
+// Put assigns value to the JSON Pointer's location, the same way Set
+// does, but returns the resulting root document instead of the previous
+// value. This makes root-level mutations representable: an empty
+// pointer replaces the document outright, something Set silently
+// ignores.
+func (ptr *JSONPointer) Put(value, document interface{}) (interface{}, error) {
+	root, _, _, err := ptr.traverseRoot(value, document, false)
+	return root, err
+}
+
+// Remove deletes the value at the JSON Pointer's location and returns
+// the resulting root document. Unlike Delete, it supports removing an
+// element from an array at the root level: since a shorter slice can't
+// always be represented by mutating document in place, Remove returns
+// the new slice as the root instead of erroring.
+//
+// An empty pointer has no parent to remove the document from, so Remove
+// (like Delete) returns an error instead of silently discarding it.
+func (ptr *JSONPointer) Remove(document interface{}) (interface{}, error) {
+	root, _, _, err := ptr.traverseRoot(nil, document, true)
+	return root, err
+}
+
+// Insert adds value at the JSON Pointer's location following the "add"
+// semantics of RFC 6902 §4.1: an object member is created or
+// overwritten, while an array element is inserted before the referenced
+// index, shifting later elements up by one. A final "-" token appends
+// to the array. Set only ever overwrites an existing array index, so
+// Insert exists to cover the append/insert cases Set does not handle.
+//
+// Insert returns the resulting root document, since inserting into (or
+// appending to) an array changes its length and can't always be
+// represented by mutating document in place.
+func (ptr *JSONPointer) Insert(value, document interface{}) (interface{}, error) {
+	if len(ptr.references) == 0 {
+		return value, nil
+	}
+
+	parent, key, err := ptr.descend(document)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := parent.(type) {
+	case map[string]interface{}:
+		t[key] = value
+		return document, nil
+	case []interface{}:
+		idx := len(t)
+		if key != "-" {
+			idx, err = strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid array index %q", key)
+			}
+			if idx < 0 || len(t) < idx {
+				return nil, fmt.Errorf("out of bound [0,%d], index %q", len(t), key)
+			}
+		}
+
+		next := make([]interface{}, 0, len(t)+1)
+		next = append(next, t[:idx]...)
+		next = append(next, value)
+		next = append(next, t[idx:]...)
+
+		if len(ptr.references) == 1 {
+			return next, nil
+		}
+
+		parentPtr := JSONPointer{references: ptr.references[:len(ptr.references)-1]}
+		return parentPtr.Put(next, document)
+	default:
+		return nil, fmt.Errorf("invalid token reference %q", key)
+	}
+}
+
+// descend walks the pointer down to its penultimate reference token and
+// returns the container it resolves to, together with the final
+// (decoded) token. It is used by operations such as Insert that need to
+// inspect and mutate the parent of the pointer's target rather than the
+// target itself.
+func (ptr *JSONPointer) descend(document interface{}) (interface{}, string, error) {
+	if len(ptr.references) == 0 {
+		return nil, "", fmt.Errorf("pointer has no parent")
+	}
+
+	current := document
+	for _, tk := range ptr.references[:len(ptr.references)-1] {
+		switch t := current.(type) {
+		case map[string]interface{}:
+			v, ok := t[tk]
+			if !ok {
+				return nil, "", fmt.Errorf("object does not have the key %q", tk)
+			}
+			current = v
+		case []interface{}:
+			idx, err := strconv.Atoi(tk)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid array index %q", tk)
+			}
+			if idx < 0 || len(t) <= idx {
+				return nil, "", fmt.Errorf("out of bound [0,%d[, index %q", len(t), idx)
+			}
+			current = t[idx]
+		default:
+			next, err := resolveStep(current, tk)
+			if err != nil {
+				return nil, "", err
+			}
+			current = next
+		}
+	}
+
+	return current, ptr.references[len(ptr.references)-1], nil
+}
+
+// String returns the pointer's JSON Pointer string representation
+// (RFC 6901 §5), with '~' and '/' escaped as '~0'/'~1' in each token.
 func (ptr *JSONPointer) String() string {
 	if len(ptr.references) == 0 {
 		return ""
 	}
-	return fmt.Sprintf("/%s", strings.Join(ptr.references, "/"))
+
+	var b strings.Builder
+	for _, tk := range ptr.references {
+		b.WriteByte('/')
+		b.WriteString(encode(tk))
+	}
+	return b.String()
+}
+
+// Fragment returns the pointer's URI fragment identifier representation
+// (RFC 6901 §6): String(), prefixed with '#', with any '%', '"',
+// non-ASCII or control character percent-encoded. The '/' that
+// separates tokens is left literal.
+func (ptr *JSONPointer) Fragment() string {
+	return "#" + escapeFragment(ptr.String())
+}
+
+// Tokens returns the pointer's reference tokens, decoded (i.e. with any
+// '~0'/'~1' escaping already resolved to '~'/'/').
+func (ptr *JSONPointer) Tokens() []string {
+	tokens := make([]string, len(ptr.references))
+	copy(tokens, ptr.references)
+	return tokens
+}
+
+// AppendToken returns a new JSON Pointer with token appended as its
+// final reference, so that callers can build up a pointer without
+// hand-escaping '~' and '/'.
+func (ptr *JSONPointer) AppendToken(token string) JSONPointer {
+	references := make([]string, len(ptr.references), len(ptr.references)+1)
+	copy(references, ptr.references)
+	references = append(references, token)
+	return JSONPointer{references: references}
 }
 
 // traverse iterates over the json document based on the JSON Pointer.
@@ -69,12 +249,45 @@ func (ptr *JSONPointer) String() string {
 // value:    the value that needs to be set.
 // document: the json document to search in.
 // remove:   indicates whether the value needs to be removed.
+//
+// traverse preserves the historical behaviour of Get/Set/Delete, which
+// report the value found (or removed) at the pointer's location rather
+// than the root document. Deleting an array element at the root level
+// is rejected here, since document itself can't be updated to reflect
+// the shorter slice; Remove supports that case by returning the new
+// root instead.
 func (ptr *JSONPointer) traverse(value, document interface{}, remove bool) (interface{}, reflect.Kind, error) {
+	if remove && len(ptr.references) == 1 {
+		if _, ok := document.([]interface{}); ok {
+			return nil, reflect.Slice, fmt.Errorf("can not delete from an array at root level")
+		}
+	}
+
+	_, current, kind, err := ptr.traverseRoot(value, document, remove)
+	return current, kind, err
+}
+
+// traverseRoot is the root-aware counterpart of traverse: alongside the
+// value found (or removed) at the pointer's location, it returns the
+// (possibly new) root document. Root-level mutations of an array's
+// length, or of the document as a whole (an empty pointer), can't be
+// represented by mutating document in place the way a root-level map
+// can, so callers that need those mutations reflected must use the
+// returned root rather than the document they passed in.
+func (ptr *JSONPointer) traverseRoot(value, document interface{}, remove bool) (interface{}, interface{}, reflect.Kind, error) {
 	kind := reflect.Invalid
 	if len(ptr.references) == 0 {
-		return document, kind, nil
+		if remove {
+			return document, nil, kind, fmt.Errorf("can not remove the root document")
+		}
+		if value != nil {
+			return value, document, reflect.ValueOf(value).Kind(), nil
+		}
+		return document, document, kind, nil
 	}
 
+	root := document
+
 	// current 'points' at the field the for-loop is currently at.
 	current := document
 
@@ -101,19 +314,15 @@ func (ptr *JSONPointer) traverse(value, document interface{}, remove bool) (inte
 
 		switch t := current.(type) {
 		case []interface{}:
-			if i == 0 && len(ptr.references) == 1 {
-				return nil, reflect.Slice, fmt.Errorf("can not delete from an array at root level")
-			}
-
 			// Raise an error condition if it fails to resolve a
 			// concrete value for any of the JSON pointer's reference
 			// tokens.
 			idx, err := strconv.Atoi(tk)
 			if err != nil {
-				return nil, reflect.Slice, fmt.Errorf("invalid array index %q", tk)
+				return root, nil, reflect.Slice, fmt.Errorf("invalid array index %q", tk)
 			}
 			if idx < 0 || len(t) <= idx {
-				return nil, reflect.Slice, fmt.Errorf("out of bound [0,%d[, index %q", len(t), idx)
+				return root, nil, reflect.Slice, fmt.Errorf("out of bound [0,%d[, index %q", len(t), idx)
 			}
 
 			// The reference token MUST contain either:
@@ -135,19 +344,18 @@ func (ptr *JSONPointer) traverse(value, document interface{}, remove bool) (inte
 				// remove value
 				if remove {
 					t = append(t[:idx], t[idx+1:]...)
-					// update previous map that contains this slice
+					// update previous map that contains this slice, or
+					// the root itself if there is no previous node
 					if 0 < i {
 						nodes[i-1].(map[string]interface{})[tokens[i-1]] = t
+					} else {
+						root = t
 					}
 				}
 			}
 		case map[string]interface{}:
-			// Evaluation of each reference token begins by decoding any
-			// escaped character sequence.  This is performed by first
-			// transforming any occurrence of the sequence '~1' to '/',
-			// and then transforming any occurrence of the sequence '~0'
-			// to '~'.
-			tk = decode(tk)
+			// Reference tokens are decoded ('~1' -> '/', then '~0' -> '~')
+			// up front in New, so tk is already the raw member name here.
 			if _, ok := t[tk]; ok {
 				// The new referenced value is the object member with
 				// the name identified by the reference token.
@@ -166,14 +374,45 @@ func (ptr *JSONPointer) traverse(value, document interface{}, remove bool) (inte
 			} else if end && value != nil {
 				t[tk] = value
 			} else {
-				return nil, reflect.Map, fmt.Errorf("object does not have the key %q", tk)
+				return root, nil, reflect.Map, fmt.Errorf("object does not have the key %q", tk)
 			}
 		default:
-			return nil, reflect.ValueOf(current).Kind(), fmt.Errorf("invalid token reference %q", tk)
+			// Neither a decoded map nor a decoded slice: fall back to
+			// JSONPointable/JSONSetable, or reflection over structs,
+			// pointers and named map/slice types, so pointers can also
+			// be evaluated against already-typed Go values.
+			next, err := resolveStep(current, tk)
+			if err != nil {
+				if end && value != nil && missingMapKey(current, tk) {
+					// resolveStep only reads existing members, but a
+					// reflected map field must be able to gain a new
+					// key too, mirroring the map[string]interface{}
+					// case above.
+					if err := setStep(current, tk, value); err != nil {
+						return root, nil, kindOf(current), err
+					}
+					break
+				}
+				return root, nil, kindOf(current), err
+			}
+
+			prev := current
+			current = next
+			if end {
+				if value != nil {
+					if err := setStep(prev, tk, value); err != nil {
+						return root, nil, kindOf(current), err
+					}
+					break
+				}
+				if remove {
+					return root, nil, kindOf(current), fmt.Errorf("removing a member of %T is not supported", prev)
+				}
+			}
 		}
 	}
 
-	return current, reflect.ValueOf(current).Kind(), nil
+	return root, unbox(current), kindOf(current), nil
 }
 
 func isPtr(ptr string) bool {
@@ -193,3 +432,31 @@ func decode(tk string) string {
 	tk = strings.Replace(tk, "~0", "~", -1)
 	return tk
 }
+
+// encode is the inverse of decode: it escapes '~' and '/' in a reference
+// token so the result can be safely joined with '/' into a pointer
+// string. The order of substitution matters here too: '~' must be
+// escaped before '/', otherwise a literal '~1' would be produced for a
+// token that only ever contained a literal '/'.
+func encode(tk string) string {
+	tk = strings.Replace(tk, "~", "~0", -1)
+	tk = strings.Replace(tk, "/", "~1", -1)
+	return tk
+}
+
+// escapeFragment percent-encodes the characters RFC 6901 §6 singles out
+// as needing escaping in a pointer's URI fragment form: '%', '"',
+// non-ASCII bytes and control characters. Everything else, including
+// the '/' that separates tokens, is left as-is.
+func escapeFragment(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '%' || c == '"' || c < 0x20 || c >= 0x7f {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
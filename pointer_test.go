@@ -90,6 +90,89 @@ func ExampleJSONPointer_Delete_error() {
 	// can not delete from an array at root level
 }
 
+func ExampleJSONPointer_Remove_root() {
+	doc := map[string]interface{}{"foo": "bar"}
+	p, _ := ptr.New("")
+	_, err := p.Remove(doc)
+	fmt.Println(err)
+
+	// Output:
+	// can not remove the root document
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		pointer string
+		want    string
+	}{
+		{"", ""},
+		{"/foo/bar", "/foo/bar"},
+		{"/a~1b", "/a~1b"},
+		{"/m~0n", "/m~0n"},
+	}
+
+	for _, tt := range tests {
+		p, err := ptr.New(tt.pointer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := p.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestAppendToken(t *testing.T) {
+	p, err := ptr.New("/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// AppendToken must escape a token containing '/' or '~' itself,
+	// rather than requiring the caller to hand-escape it.
+	p = p.AppendToken("a/b~c")
+
+	const want = "/foo/a~1b~0c"
+	if got := p.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	wantTokens := []string{"foo", "a/b~c"}
+	if got := p.Tokens(); !reflect.DeepEqual(got, wantTokens) {
+		t.Errorf("Tokens() = %v, want %v", got, wantTokens)
+	}
+}
+
+func TestFragment(t *testing.T) {
+	tests := []struct {
+		pointer  string
+		fragment string
+	}{
+		{"", "#"},
+		{"/foo/bar", "#/foo/bar"},
+		{"/c%d", "#/c%25d"},
+		{"/k\"l", "#/k%22l"},
+	}
+
+	for _, tt := range tests {
+		p, err := ptr.New(tt.pointer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := p.Fragment(); got != tt.fragment {
+			t.Errorf("Fragment() = %q, want %q", got, tt.fragment)
+		}
+
+		back, err := ptr.NewFromFragment(tt.fragment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := back.String(); got != tt.pointer {
+			t.Errorf("NewFromFragment(%q).String() = %q, want %q", tt.fragment, got, tt.pointer)
+		}
+	}
+}
+
 func ExampleJSONPointer_Get() {
 	doc := map[string]interface{}{
 		"foo": []interface{}{"bar", "baz"},
@@ -0,0 +1,184 @@
+package ptr
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// GetWithParents is like Get, but additionally returns the chain of
+// ancestor containers visited to reach the value, and the reference
+// token used to step from each ancestor to the next. parents[0] is the
+// root document and tokens[0] is ptr's first reference token; parents
+// and tokens are always the same length as the pointer's token count.
+// It exists to let RelativePointer.Evaluate walk back up from a base
+// location.
+func (ptr *JSONPointer) GetWithParents(document interface{}) (interface{}, []interface{}, []string, error) {
+	if len(ptr.references) == 0 {
+		return document, nil, nil, nil
+	}
+
+	parents := make([]interface{}, len(ptr.references))
+	tokens := make([]string, len(ptr.references))
+
+	current := document
+	for i, tk := range ptr.references {
+		parents[i] = unbox(current)
+		tokens[i] = tk
+
+		next, err := step(current, tk)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		current = next
+	}
+
+	return unbox(current), parents, tokens, nil
+}
+
+// step performs read-only, single-token navigation: the child of
+// current identified by tk. It handles decoded maps and slices
+// directly, the same way traverseRoot's fast path does, and falls back
+// to resolveStep (JSONPointable, reflection) for everything else.
+func step(current interface{}, tk string) (interface{}, error) {
+	switch t := current.(type) {
+	case map[string]interface{}:
+		v, ok := t[tk]
+		if !ok {
+			return nil, fmt.Errorf("object does not have the key %q", tk)
+		}
+		return v, nil
+	case []interface{}:
+		idx, err := strconv.Atoi(tk)
+		if err != nil {
+			return nil, fmt.Errorf("invalid array index %q", tk)
+		}
+		if idx < 0 || len(t) <= idx {
+			return nil, fmt.Errorf("out of bound [0,%d[, index %q", len(t), idx)
+		}
+		return t[idx], nil
+	default:
+		return resolveStep(current, tk)
+	}
+}
+
+// RelativePointer represents a Relative JSON Pointer, as described by
+// draft-bhutton-relative-json-pointer: a non-negative integer counting
+// how many levels to ascend from a base location, optionally followed
+// by a "+N"/"-N" adjustment to the index that placed the ascended node
+// in its own parent, followed by either a normal JSON Pointer suffix or
+// a trailing "#" asking for the ascended node's key/index in its
+// parent instead of its value.
+type RelativePointer struct {
+	levels    int
+	adjust    int
+	suffix    JSONPointer
+	wantIndex bool
+}
+
+// NewRelative parses s as a Relative JSON Pointer.
+func NewRelative(s string) (RelativePointer, error) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return RelativePointer{}, fmt.Errorf("a Relative JSON Pointer must start with a non-negative integer")
+	}
+
+	levels, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return RelativePointer{}, fmt.Errorf("invalid level count %q: %w", s[:i], err)
+	}
+	rest := s[i:]
+
+	var adjust int
+	if len(rest) > 0 && (rest[0] == '+' || rest[0] == '-') {
+		j := 1
+		for j < len(rest) && rest[j] >= '0' && rest[j] <= '9' {
+			j++
+		}
+		if j == 1 {
+			return RelativePointer{}, fmt.Errorf("invalid index adjustment %q", rest[:1])
+		}
+		adjust, err = strconv.Atoi(rest[:j])
+		if err != nil {
+			return RelativePointer{}, fmt.Errorf("invalid index adjustment %q: %w", rest[:j], err)
+		}
+		rest = rest[j:]
+	}
+
+	if rest == "#" {
+		return RelativePointer{levels: levels, adjust: adjust, wantIndex: true}, nil
+	}
+
+	suffix, err := New(rest)
+	if err != nil {
+		return RelativePointer{}, err
+	}
+
+	return RelativePointer{levels: levels, adjust: adjust, suffix: suffix}, nil
+}
+
+// Evaluate resolves the relative pointer against document, treating
+// base as the JSON Pointer that locates the node the relative pointer
+// is relative to.
+func (rp RelativePointer) Evaluate(document interface{}, base JSONPointer) (interface{}, error) {
+	value, parents, tokens, err := base.GetWithParents(document)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(parents)
+	if rp.levels > n {
+		return nil, fmt.Errorf("relative pointer ascends %d level(s) past the root", rp.levels-n)
+	}
+
+	path := make([]interface{}, n+1)
+	copy(path, parents)
+	path[n] = value
+
+	target := path[n-rp.levels]
+
+	var parent interface{}
+	var token string
+	if n-rp.levels-1 >= 0 {
+		parent = path[n-rp.levels-1]
+		token = tokens[n-rp.levels-1]
+	}
+
+	if rp.adjust != 0 {
+		if parent == nil {
+			return nil, fmt.Errorf("index adjustment requires a parent to adjust the index within")
+		}
+		arr, ok := parent.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("index adjustment requires an array parent, got %T", parent)
+		}
+		oldIdx, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, fmt.Errorf("index adjustment requires a numeric reference token, got %q", token)
+		}
+		newIdx := oldIdx + rp.adjust
+		if newIdx < 0 || newIdx >= len(arr) {
+			return nil, fmt.Errorf("out of bound [0,%d[, index %d", len(arr), newIdx)
+		}
+		target = arr[newIdx]
+		token = strconv.Itoa(newIdx)
+	}
+
+	if rp.wantIndex {
+		if parent == nil {
+			return nil, fmt.Errorf("the root has no key or index in a parent")
+		}
+		if _, ok := parent.(map[string]interface{}); ok {
+			return token, nil
+		}
+		idx, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid array index %q", token)
+		}
+		return idx, nil
+	}
+
+	return rp.suffix.Get(target)
+}
@@ -0,0 +1,178 @@
+package ptr_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	ptr "github.com/oas3/json-pointer"
+)
+
+// RFC: https://datatracker.ietf.org/doc/html/draft-bhutton-relative-json-pointer-00#section-5.1
+func ExampleRelativePointer_Evaluate() {
+	doc := map[string]interface{}{
+		"foo": []interface{}{"bar", "baz"},
+	}
+
+	base, _ := ptr.New("/foo/1")
+	rel, _ := ptr.NewRelative("0-1")
+
+	v, _ := rel.Evaluate(doc, base)
+	fmt.Println(v)
+
+	// Output:
+	// bar
+}
+
+func TestRelativeEvaluate(t *testing.T) {
+	doc := map[string]interface{}{
+		"foo": map[string]interface{}{
+			"bar": []interface{}{"a", "b", "c"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		relative string
+		want     interface{}
+	}{
+		{"stay in place", "0", "b"},
+		{"ascend then index suffix", "1/0", "a"},
+		{"sibling via negative adjust", "0-1", "a"},
+		{"sibling via positive adjust", "0+1", "c"},
+		{"index of origin in its array", "0#", 1},
+		{"key of the array in its parent", "1#", "bar"},
+		{"key of the parent in root", "2#", "foo"},
+	}
+
+	base, err := ptr.New("/foo/bar/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rel, err := ptr.NewRelative(tt.relative)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := rel.Evaluate(doc, base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate(%q) = %v, want %v", tt.relative, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelativeCombinedForms(t *testing.T) {
+	// "2/foo/bar": ascend 2 levels to the root document, then resolve a
+	// normal pointer suffix from there.
+	doc := map[string]interface{}{
+		"foo":   map[string]interface{}{"bar": "found"},
+		"other": map[string]interface{}{"x": 1.0, "y": 2.0},
+	}
+	base, err := ptr.New("/other/x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := ptr.NewRelative("2/foo/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := rel.Evaluate(doc, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "found" {
+		t.Errorf("Evaluate(2/foo/bar) = %v, want %q", got, "found")
+	}
+
+	// "1+1/-": ascend 1 level to the sibling array one over (the index
+	// adjustment), then append a normal-pointer suffix identifying the
+	// (not yet existing) append position. It's a pointer meant to be fed
+	// to an add/insert operation, not dereferenced, so only parsing is
+	// exercised here.
+	if _, err := ptr.NewRelative("1+1/-"); err != nil {
+		t.Fatalf("NewRelative(1+1/-): %v", err)
+	}
+
+	// The same "N+M" adjustment applied to real data: ascending into the
+	// array that holds the origin's own container and stepping one
+	// sibling over.
+	matrix := map[string]interface{}{
+		"matrix": []interface{}{
+			[]interface{}{"a", "b"},
+			[]interface{}{"c", "d", "e"},
+		},
+	}
+	mbase, err := ptr.New("/matrix/0/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mrel, err := ptr.NewRelative("1+1/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = mrel.Evaluate(matrix, mbase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "c" {
+		t.Errorf("Evaluate(1+1/0) = %v, want %q", got, "c")
+	}
+}
+
+func TestRelativeAscendPastRoot(t *testing.T) {
+	doc := map[string]interface{}{"foo": "bar"}
+
+	base, err := ptr.New("/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := ptr.NewRelative("5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rel.Evaluate(doc, base)
+	if err == nil {
+		t.Fatal("expected an error ascending past the root")
+	}
+	if !strings.Contains(err.Error(), "past the root") {
+		t.Errorf("err = %v, want a message about ascending past the root", err)
+	}
+}
+
+func TestRelativeAdjustNonNumericToken(t *testing.T) {
+	doc := map[string]interface{}{
+		"foo": map[string]interface{}{"bar": "baz"},
+	}
+
+	base, err := ptr.New("/foo/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := ptr.NewRelative("0+1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rel.Evaluate(doc, base)
+	if err == nil {
+		t.Fatal("expected an error adjusting the index of a non-array parent")
+	}
+}
+
+func TestNewRelativeInvalid(t *testing.T) {
+	tests := []string{"", "#", "+1", "a/b", "1+/foo"}
+
+	for _, s := range tests {
+		if _, err := ptr.NewRelative(s); err == nil {
+			t.Errorf("NewRelative(%q): expected an error", s)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package ptr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// GetFromReader is like Get, but reads document from r as a stream of
+// JSON tokens instead of unmarshaling it up front. It descends only
+// into the object keys and array indices named by the pointer, skipping
+// over sibling subtrees without decoding them, and only decodes the
+// value at the pointer's location once it's reached. This avoids the
+// O(document) allocation cost of json.Unmarshal followed by Get for a
+// single keyed lookup in a large payload.
+func (ptr *JSONPointer) GetFromReader(r io.Reader) (interface{}, error) {
+	raw, err := ptr.GetRawFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// GetRawFromReader is like GetFromReader, but returns the matched
+// subtree as a json.RawMessage instead of decoding it, so a caller that
+// only needs to re-emit or lazily parse the value can skip the
+// intermediate unmarshal.
+func (ptr *JSONPointer) GetRawFromReader(r io.Reader) (json.RawMessage, error) {
+	dec := json.NewDecoder(r)
+
+	if len(ptr.references) > 0 {
+		if err := descendTokens(dec, ptr.references); err != nil {
+			return nil, err
+		}
+	}
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// descendTokens advances dec's token stream, without decoding anything,
+// until it is positioned right before the value named by tokens[0],
+// then recurses for the remaining tokens.
+func descendTokens(dec *json.Decoder, tokens []string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return fmt.Errorf("reached a scalar value while resolving token %q", tokens[0])
+	}
+
+	switch delim {
+	case '{':
+		return descendObject(dec, tokens)
+	case '[':
+		return descendArray(dec, tokens)
+	default:
+		return fmt.Errorf("reached %q while resolving token %q", delim, tokens[0])
+	}
+}
+
+// descendObject consumes key/value pairs from dec, which must be
+// positioned right after an object's opening '{', skipping every value
+// whose key isn't tokens[0] until it finds one that is.
+func descendObject(dec *json.Decoder, tokens []string) error {
+	target := tokens[0]
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := keyTok.(string)
+
+		if key != target {
+			if err := skipValue(dec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if len(tokens) == 1 {
+			return nil
+		}
+		return descendTokens(dec, tokens[1:])
+	}
+	return fmt.Errorf("object does not have the key %q", target)
+}
+
+// descendArray consumes elements from dec, which must be positioned
+// right after an array's opening '[', skipping every element before
+// tokens[0]'s index.
+func descendArray(dec *json.Decoder, tokens []string) error {
+	idx, err := strconv.Atoi(tokens[0])
+	if err != nil || idx < 0 {
+		return fmt.Errorf("invalid array index %q", tokens[0])
+	}
+
+	for i := 0; dec.More(); i++ {
+		if i == idx {
+			if len(tokens) == 1 {
+				return nil
+			}
+			return descendTokens(dec, tokens[1:])
+		}
+		if err := skipValue(dec); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("out of bound index %q", tokens[0])
+}
+
+// skipValue consumes exactly one JSON value from dec without decoding
+// it, used to fast-forward past sibling values that aren't on the path
+// to the pointer's target.
+func skipValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil // scalar, already consumed
+	}
+
+	for dec.More() {
+		if delim == '{' {
+			if _, err := dec.Token(); err != nil { // key
+				return err
+			}
+		}
+		if err := skipValue(dec); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // closing delimiter
+	return err
+}
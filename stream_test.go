@@ -0,0 +1,89 @@
+package ptr_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	ptr "github.com/oas3/json-pointer"
+)
+
+func ExampleJSONPointer_GetFromReader() {
+	const doc = `{"foo": ["bar", "baz"], "skip": {"large": "ignored"}}`
+
+	p, _ := ptr.New("/foo/1")
+	v, _ := p.GetFromReader(strings.NewReader(doc))
+	fmt.Println(v)
+
+	// Output:
+	// baz
+}
+
+func TestGetFromReader(t *testing.T) {
+	const doc = `{
+		"a": {"nested": true, "skip": [1, 2, 3]},
+		"b": ["x", {"y": 42}, "z"],
+		"c": "top"
+	}`
+
+	tests := []struct {
+		pointer string
+		want    interface{}
+	}{
+		{"", map[string]interface{}{
+			"a": map[string]interface{}{"nested": true, "skip": []interface{}{1.0, 2.0, 3.0}},
+			"b": []interface{}{"x", map[string]interface{}{"y": 42.0}, "z"},
+			"c": "top",
+		}},
+		{"/c", "top"},
+		{"/a/nested", true},
+		{"/b/1/y", 42.0},
+	}
+
+	for _, tt := range tests {
+		p, err := ptr.New(tt.pointer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := p.GetFromReader(strings.NewReader(doc))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.pointer, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.pointer, got, tt.want)
+		}
+	}
+}
+
+func TestGetRawFromReader(t *testing.T) {
+	const doc = `{"a": {"b": [1, 2, {"c": 3}]}}`
+
+	p, err := ptr.New("/a/b/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := p.GetRawFromReader(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(raw)), `{"c": 3}`; got != want {
+		t.Errorf("GetRawFromReader() = %s, want %s", got, want)
+	}
+}
+
+func TestGetFromReaderErrors(t *testing.T) {
+	const doc = `{"foo": ["bar"]}`
+
+	tests := []string{"/missing", "/foo/9", "/foo/bar"}
+
+	for _, pointer := range tests {
+		p, err := ptr.New(pointer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.GetFromReader(strings.NewReader(doc)); err == nil {
+			t.Errorf("%s: expected an error", pointer)
+		}
+	}
+}